@@ -0,0 +1,69 @@
+package quic
+
+import (
+	"crypto/rand"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// A ConnectionIDGenerator generates the Connection IDs a Server mints as
+// Source Connection IDs, via Server.newConnectionID. Plugging in a custom
+// implementation lets a deployment encode its own information into the IDs
+// it generates, e.g. a server identifier that lets a load balancer route
+// packets to the right backend without decrypting them.
+type ConnectionIDGenerator interface {
+	// GenerateConnectionID generates a new Connection ID of the given
+	// length. length is always the value returned by ConnectionIDLen.
+	GenerateConnectionID(length int) (protocol.ConnectionID, error)
+	// ConnectionIDLen returns the length of the Connection IDs generated by
+	// this generator. All Connection IDs generated for a given connection
+	// must share this length.
+	ConnectionIDLen() int
+}
+
+// randomConnectionIDGenerator is the default ConnectionIDGenerator, used
+// whenever Config.ConnectionIDGenerator isn't set. It hands out
+// cryptographically random Connection IDs of a fixed length.
+type randomConnectionIDGenerator struct {
+	connIDLen int
+}
+
+var _ ConnectionIDGenerator = &randomConnectionIDGenerator{}
+
+func (g *randomConnectionIDGenerator) GenerateConnectionID(length int) (protocol.ConnectionID, error) {
+	connID := make(protocol.ConnectionID, length)
+	if _, err := rand.Read(connID); err != nil {
+		return nil, err
+	}
+	return connID, nil
+}
+
+func (g *randomConnectionIDGenerator) ConnectionIDLen() int {
+	return g.connIDLen
+}
+
+// Config contains all configuration data needed to run a QUIC server or
+// client.
+type Config struct {
+	// ConnectionIDGenerator generates the Connection IDs used for a
+	// session's Source Connection ID. If unset, Connection IDs are
+	// generated at random, with a length of
+	// protocol.DefaultConnectionIDLength.
+	ConnectionIDGenerator ConnectionIDGenerator
+
+	// SupportedVersions is the ordered list of QUIC versions a Server
+	// advertises to a client that requested one it doesn't support. If
+	// unset, protocol.SupportedVersions is used. Set via
+	// Server.SetSupportedVersions rather than directly, so a Server always
+	// has a non-nil Config to store it on.
+	SupportedVersions []protocol.VersionNumber
+}
+
+// connectionIDGenerator returns the Config's ConnectionIDGenerator, falling
+// back to the default random one if none is set (or if c itself is nil).
+func (c *Config) connectionIDGenerator() ConnectionIDGenerator {
+	if c != nil && c.ConnectionIDGenerator != nil {
+		return c.ConnectionIDGenerator
+	}
+	return &randomConnectionIDGenerator{connIDLen: protocol.DefaultConnectionIDLength}
+}
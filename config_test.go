@@ -0,0 +1,50 @@
+package quic
+
+import (
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type constantConnectionIDGenerator struct {
+	id protocol.ConnectionID
+}
+
+func (g *constantConnectionIDGenerator) GenerateConnectionID(length int) (protocol.ConnectionID, error) {
+	return g.id, nil
+}
+
+func (g *constantConnectionIDGenerator) ConnectionIDLen() int {
+	return g.id.Len()
+}
+
+var _ = Describe("Config", func() {
+	It("generates random Connection IDs of the default length when unconfigured", func() {
+		gen := (&Config{}).connectionIDGenerator()
+		Expect(gen.ConnectionIDLen()).To(Equal(protocol.DefaultConnectionIDLength))
+
+		id1, err := gen.GenerateConnectionID(gen.ConnectionIDLen())
+		Expect(err).ToNot(HaveOccurred())
+		id2, err := gen.GenerateConnectionID(gen.ConnectionIDLen())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id1).To(HaveLen(protocol.DefaultConnectionIDLength))
+		Expect(id1).ToNot(Equal(id2))
+	})
+
+	It("lets a Server use a custom ConnectionIDGenerator", func() {
+		want := protocol.ConnectionID{1, 3, 3, 7}
+		s := &Server{Config: &Config{ConnectionIDGenerator: &constantConnectionIDGenerator{id: want}}}
+
+		id, err := s.newConnectionID()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(Equal(want))
+	})
+
+	It("falls back to a random Connection ID when the Server has no Config", func() {
+		s := &Server{}
+		id, err := s.newConnectionID()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id).To(HaveLen(protocol.DefaultConnectionIDLength))
+	})
+})
@@ -0,0 +1,22 @@
+package quic
+
+import (
+	"fmt"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// A PublicResetError is the error a Session is closed with when the peer
+// (or an on-path attacker spoofing the peer) sends a gQUIC Public Reset
+// packet. Unlike a CONNECTION_CLOSE, a Public Reset is unauthenticated, so
+// callers that need to tell a legitimate teardown from a possibly spoofed
+// one can match on this type, e.g. using errors.As.
+type PublicResetError struct {
+	// RejectedPacketNumber is the packet number that made the peer give up
+	// on the connection.
+	RejectedPacketNumber protocol.PacketNumber
+}
+
+func (e *PublicResetError) Error() string {
+	return fmt.Sprintf("received a Public Reset for packet number %d", e.RejectedPacketNumber)
+}
@@ -0,0 +1,35 @@
+package protocol
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// DefaultConnectionIDLength is the connection ID length used by gQUIC, before
+// variable-length connection IDs were introduced
+const DefaultConnectionIDLength = 8
+
+// MaxConnectionIDLen is the maximum length of a Connection ID, as defined by
+// the IETF QUIC invariants
+const MaxConnectionIDLen = 20
+
+// A ConnectionID in QUIC
+type ConnectionID []byte
+
+// String returns a string representation of the connection ID, for logging purposes
+func (c ConnectionID) String() string {
+	if len(c) == 0 {
+		return "(empty)"
+	}
+	return fmt.Sprintf("%#x", []byte(c))
+}
+
+// Equal says if two connection IDs are equal
+func (c ConnectionID) Equal(other ConnectionID) bool {
+	return bytes.Equal(c, other)
+}
+
+// Len returns the length of the connection ID in bytes
+func (c ConnectionID) Len() int {
+	return len(c)
+}
@@ -0,0 +1,47 @@
+// Package protocol contains constants and minor functions that are defined in the QUIC protocol
+package protocol
+
+// A ByteCount is used to count bytes
+type ByteCount uint64
+
+// MaxByteCount is the maximum value of a ByteCount
+const MaxByteCount = ByteCount(1<<64 - 1)
+
+// MaxPacketBufferSize is the maximum size of a QUIC packet
+const MaxPacketBufferSize ByteCount = 1452
+
+// A PacketNumber in QUIC
+type PacketNumber uint64
+
+// PacketNumberLen is the length of the packet number in bytes
+type PacketNumberLen uint8
+
+const (
+	// PacketNumberLenInvalid is the default value and not a valid length for a packet number
+	PacketNumberLenInvalid PacketNumberLen = 0
+	// PacketNumberLen1 is a packet number length of 1 byte
+	PacketNumberLen1 PacketNumberLen = 1
+	// PacketNumberLen2 is a packet number length of 2 bytes
+	PacketNumberLen2 PacketNumberLen = 2
+	// PacketNumberLen4 is a packet number length of 4 bytes
+	PacketNumberLen4 PacketNumberLen = 4
+	// PacketNumberLen6 is a packet number length of 6 bytes
+	PacketNumberLen6 PacketNumberLen = 6
+)
+
+// A Perspective determines if we're acting as a server or a client
+type Perspective int
+
+// the perspectives
+const (
+	PerspectiveServer Perspective = 1
+	PerspectiveClient Perspective = 2
+)
+
+// Opposite returns the perspective of the peer
+func (p Perspective) Opposite() Perspective {
+	if p == PerspectiveClient {
+		return PerspectiveServer
+	}
+	return PerspectiveClient
+}
@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+)
+
+// VersionNumber is a version number as encoded on the wire
+type VersionNumber uint32
+
+// gQUIC versions are encoded on the wire as an ASCII tag of the form "Qnnn",
+// e.g. Version39 is encoded as the 4 bytes "Q039"
+const (
+	// VersionWhatever is used for when the version doesn't matter
+	VersionWhatever VersionNumber = 0
+
+	// Version39 is gQUIC version 39
+	Version39 VersionNumber = 0x51303339 // "Q039"
+
+	// VersionTLS is an IETF QUIC version, using the long/short header format
+	// instead of the gQUIC Public Header, as shipped by quic-go v0.40+
+	VersionTLS VersionNumber = 0xff00001d // draft-29
+)
+
+// SupportedVersions lists the versions that are supported by this implementation of QUIC, in order of preference
+var SupportedVersions = []VersionNumber{
+	Version39,
+	VersionTLS,
+}
+
+// IsLongHeaderVersion says whether a Version uses the IETF QUIC long/short
+// header format. gQUIC versions are tagged "Qnnn" on the wire (the high
+// byte is the ASCII 'Q'); every other version number except
+// VersionWhatever (0) is an IETF QUIC version, which never collides with a gQUIC tag since 'Q' (0x51) is not a
+// valid high byte for an IETF draft or final version number.
+func (vn VersionNumber) IsLongHeaderVersion() bool {
+	return vn != VersionWhatever && uint32(vn)>>24 != uint32('Q')
+}
+
+// IsSupportedVersion returns true if the server supports this version
+func IsSupportedVersion(supported []VersionNumber, v VersionNumber) bool {
+	for _, t := range supported {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// GreaseVersion returns a reserved version number of the form 0x?a?a?a?a, as
+// recommended by RFC 9000 Section 15.3. A server that offers one of these in
+// its Version Negotiation list forces clients to exercise their
+// unknown-version handling, instead of accidentally coming to depend on the
+// exact set of versions this implementation happens to support today.
+func GreaseVersion() VersionNumber {
+	var b [4]byte
+	rand.Read(b[:])
+	for i := range b {
+		b[i] = b[i]&0xf0 | 0x0a
+	}
+	return VersionNumber(binary.BigEndian.Uint32(b[:]))
+}
+
+// String returns the string representation of the version for logging purposes
+func (vn VersionNumber) String() string {
+	if vn == VersionWhatever {
+		return "whatever"
+	}
+	v := uint32(vn)
+	if v>>24 != uint32('Q') {
+		return fmt.Sprintf("%#x", v)
+	}
+	num := int((v>>16)&0xff-'0')*100 + int((v>>8)&0xff-'0')*10 + int(v&0xff-'0')
+	return fmt.Sprintf("gQUIC %d", num)
+}
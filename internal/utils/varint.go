@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ReadVarInt reads a number in the QUIC variable-length integer encoding
+func ReadVarInt(b *bytes.Reader) (uint64, error) {
+	firstByte, err := b.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if err := b.UnreadByte(); err != nil {
+		return 0, err
+	}
+	length := 1 << (firstByte >> 6)
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(b, buf); err != nil {
+		return 0, err
+	}
+	buf[0] &= 0x3f
+	var val uint64
+	for _, v := range buf {
+		val = val<<8 | uint64(v)
+	}
+	return val, nil
+}
+
+// WriteVarInt writes a number in the QUIC variable-length integer encoding
+func WriteVarInt(b *bytes.Buffer, i uint64) {
+	switch {
+	case i <= 63:
+		b.WriteByte(uint8(i))
+	case i <= 16383:
+		b.WriteByte(uint8(i>>8) | 0x40)
+		b.WriteByte(uint8(i))
+	case i <= 1073741823:
+		b.WriteByte(uint8(i>>24) | 0x80)
+		b.WriteByte(uint8(i >> 16))
+		b.WriteByte(uint8(i >> 8))
+		b.WriteByte(uint8(i))
+	case i <= 4611686018427387903:
+		b.WriteByte(uint8(i>>56) | 0xc0)
+		b.WriteByte(uint8(i >> 48))
+		b.WriteByte(uint8(i >> 40))
+		b.WriteByte(uint8(i >> 32))
+		b.WriteByte(uint8(i >> 24))
+		b.WriteByte(uint8(i >> 16))
+		b.WriteByte(uint8(i >> 8))
+		b.WriteByte(uint8(i))
+	default:
+		panic(fmt.Sprintf("%#x doesn't fit into 62 bits", i))
+	}
+}
@@ -0,0 +1,46 @@
+package wire
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// ParseHeader parses the header of a QUIC packet. It is the single entry
+// point callers (e.g. the packet handler that demultiplexes incoming
+// packets) should use, since a packet's wire format isn't known upfront:
+//
+//   - If the high bit of the first byte is set, this is an IETF QUIC long
+//     header. Long headers are self-describing: they carry a Version, so
+//     ParseHeader can always pick the right parser for them.
+//   - Otherwise, this is either a gQUIC Public Header or an IETF QUIC short
+//     header; neither carries a Version on the wire. The caller must
+//     already know which one to expect - typically because it looked up
+//     the Version that was negotiated for this Connection ID - and passes
+//     that classification in via shortHeaderIsIETF and shortHeaderConnIDLen.
+func ParseHeader(b *bytes.Reader, perspective protocol.Perspective, shortHeaderIsIETF bool, shortHeaderConnIDLen int) (*Header, error) {
+	firstByte, err := b.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.UnreadByte(); err != nil {
+		return nil, err
+	}
+	if firstByte&ietfLongHeaderFlag != 0 {
+		return parseLongHeader(b)
+	}
+	if shortHeaderIsIETF {
+		return parseShortHeader(b, shortHeaderConnIDLen)
+	}
+	return parsePublicHeader(b, perspective)
+}
+
+// ParseHeaderForVersion parses the header of a QUIC packet whose negotiated
+// Version is already known, e.g. because the caller looked it up for this
+// packet's Connection ID. It's a convenience wrapper around ParseHeader that
+// derives shortHeaderIsIETF from version via VersionNumber.IsLongHeaderVersion,
+// so callers that track a Version don't need to re-derive the classification
+// themselves.
+func ParseHeaderForVersion(b *bytes.Reader, perspective protocol.Perspective, version protocol.VersionNumber, shortHeaderConnIDLen int) (*Header, error) {
+	return ParseHeader(b, perspective, version.IsLongHeaderVersion(), shortHeaderConnIDLen)
+}
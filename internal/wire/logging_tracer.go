@@ -0,0 +1,34 @@
+package wire
+
+import (
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/utils"
+)
+
+// LoggingTracer is the default Tracer: it adapts the structured events back
+// onto a utils.Logger, the same sink logPublicHeader has always written to.
+// It exists so that code built against the Tracer interface keeps behaving
+// like the old printf logging when no qlog output has been configured.
+type LoggingTracer struct {
+	Logger utils.Logger
+}
+
+var _ Tracer = &LoggingTracer{}
+
+func (t *LoggingTracer) ReceivedPacket(hdr *Header, size protocol.ByteCount) {
+	hdr.logPublicHeader(t.Logger)
+	t.Logger.Debugf("\tsize: %d bytes", size)
+}
+
+func (t *LoggingTracer) SentPacket(hdr *Header, size protocol.ByteCount) {
+	hdr.logPublicHeader(t.Logger)
+	t.Logger.Debugf("\tsize: %d bytes", size)
+}
+
+func (t *LoggingTracer) DroppedPacket(reason string) {
+	t.Logger.Debugf("dropped packet: %s", reason)
+}
+
+func (t *LoggingTracer) NegotiatedVersion(chosen protocol.VersionNumber, clientVersions, serverVersions []protocol.VersionNumber) {
+	t.Logger.Debugf("negotiated version %s (client offered %s, server offered %s)", chosen, clientVersions, serverVersions)
+}
@@ -0,0 +1,181 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/utils"
+	"github.com/lucas-clemente/quic-go/qerr"
+)
+
+// ietfLongHeaderFlag is the high bit of the first byte that, per the QUIC
+// invariants, distinguishes a long header packet from a short header one.
+const ietfLongHeaderFlag = 0x80
+
+// ietfLongHeaderFixedBit must always be set on long header packets, the same
+// way the short header's ietfShortHeaderFixedBit is: it lets endpoints
+// distinguish a (possibly future) greased long header from garbage.
+const ietfLongHeaderFixedBit = 0x40
+
+// validateConnectionIDLen checks that l is a length the long header's DCIL
+// and SCIL fields can carry: a plain byte, 0 to protocol.MaxConnectionIDLen.
+// This used to be a 4-bit nibble restricted to 0 or 4-18 bytes; it was
+// widened to a full byte so that a ConnectionIDGenerator can hand out
+// Connection IDs of any length up to the invariants' maximum.
+func validateConnectionIDLen(l int) error {
+	if l < 0 || l > protocol.MaxConnectionIDLen {
+		return fmt.Errorf("invalid connection ID length: %d bytes", l)
+	}
+	return nil
+}
+
+// parseLongHeader parses the long header of an IETF QUIC packet (Initial,
+// 0-RTT, Handshake or Retry). The caller must not have consumed the first
+// byte yet.
+func parseLongHeader(b *bytes.Reader) (*Header, error) {
+	firstByte, err := b.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if firstByte&ietfLongHeaderFixedBit == 0 {
+		return nil, qerr.Error(qerr.InvalidPacketHeader, "long header packet without the fixed bit set")
+	}
+	h := &Header{
+		IsLongHeader:    true,
+		Type:            PacketType((firstByte & 0x30) >> 4),
+		PacketNumberLen: protocol.PacketNumberLen(firstByte&0x3) + 1,
+	}
+
+	var v uint32
+	if err := binary.Read(b, binary.BigEndian, &v); err != nil {
+		return nil, err
+	}
+	h.Version = protocol.VersionNumber(v)
+
+	destLenByte, err := b.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	destLen := int(destLenByte)
+	if err := validateConnectionIDLen(destLen); err != nil {
+		return nil, err
+	}
+	h.DestConnectionID = make(protocol.ConnectionID, destLen)
+	if _, err := io.ReadFull(b, h.DestConnectionID); err != nil {
+		return nil, err
+	}
+	srcLenByte, err := b.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	srcLen := int(srcLenByte)
+	if err := validateConnectionIDLen(srcLen); err != nil {
+		return nil, err
+	}
+	h.SrcConnectionID = make(protocol.ConnectionID, srcLen)
+	if _, err := io.ReadFull(b, h.SrcConnectionID); err != nil {
+		return nil, err
+	}
+
+	if h.Type == PacketTypeInitial {
+		tokenLen, err := utils.ReadVarInt(b)
+		if err != nil {
+			return nil, err
+		}
+		// tokenLen comes straight off the wire as a varint, which can claim
+		// up to 2^62-1 bytes; without this check, make([]byte, tokenLen)
+		// below would try to allocate that much for a single undersized
+		// packet and panic rather than return a parse error.
+		if tokenLen > uint64(b.Len()) {
+			return nil, qerr.Error(qerr.InvalidPacketHeader, "token length longer than the remaining packet length")
+		}
+		h.Token = make([]byte, tokenLen)
+		if _, err := io.ReadFull(b, h.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	if h.Type == PacketTypeRetry {
+		origConnIDLenByte, err := b.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		origLen := int(origConnIDLenByte)
+		if err := validateConnectionIDLen(origLen); err != nil {
+			return nil, err
+		}
+		h.OriginalDestConnectionID = make(protocol.ConnectionID, origLen)
+		if _, err := io.ReadFull(b, h.OriginalDestConnectionID); err != nil {
+			return nil, err
+		}
+		h.Token = make([]byte, b.Len())
+		if _, err := io.ReadFull(b, h.Token); err != nil {
+			return nil, err
+		}
+		return h, nil
+	}
+
+	length, err := utils.ReadVarInt(b)
+	if err != nil {
+		return nil, err
+	}
+	h.Length = protocol.ByteCount(length)
+
+	pn, err := readPacketNumber(b, h.PacketNumberLen)
+	if err != nil {
+		return nil, err
+	}
+	h.PacketNumber = pn
+
+	return h, nil
+}
+
+// writeLongHeader writes the long header of an IETF QUIC packet.
+func (h *Header) writeLongHeader(b *bytes.Buffer) error {
+	if err := validateConnectionIDLen(len(h.DestConnectionID)); err != nil {
+		return err
+	}
+	if err := validateConnectionIDLen(len(h.SrcConnectionID)); err != nil {
+		return err
+	}
+
+	// a Retry packet carries no packet number; the low 2 bits of the first
+	// byte are reserved (and unused) for it instead
+	var pnLenBits uint8
+	if h.Type != PacketTypeRetry {
+		if h.PacketNumberLen < protocol.PacketNumberLen1 || h.PacketNumberLen > protocol.PacketNumberLen4 {
+			return errInvalidPacketNumberLen
+		}
+		pnLenBits = uint8(h.PacketNumberLen - 1)
+	}
+
+	firstByte := ietfLongHeaderFlag | ietfLongHeaderFixedBit | uint8(h.Type)<<4 | pnLenBits
+	b.WriteByte(firstByte)
+	if err := binary.Write(b, binary.BigEndian, uint32(h.Version)); err != nil {
+		return err
+	}
+	b.WriteByte(uint8(len(h.DestConnectionID)))
+	b.Write(h.DestConnectionID)
+	b.WriteByte(uint8(len(h.SrcConnectionID)))
+	b.Write(h.SrcConnectionID)
+
+	if h.Type == PacketTypeInitial {
+		utils.WriteVarInt(b, uint64(len(h.Token)))
+		b.Write(h.Token)
+	}
+	if h.Type == PacketTypeRetry {
+		if err := validateConnectionIDLen(len(h.OriginalDestConnectionID)); err != nil {
+			return err
+		}
+		b.WriteByte(uint8(len(h.OriginalDestConnectionID)))
+		b.Write(h.OriginalDestConnectionID)
+		b.Write(h.Token)
+		return nil
+	}
+
+	utils.WriteVarInt(b, uint64(h.Length))
+	return writePacketNumber(b, h.PacketNumber, h.PacketNumberLen)
+}
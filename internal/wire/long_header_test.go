@@ -0,0 +1,129 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Long Header", func() {
+	It("writes and parses an Initial packet", func() {
+		hdr := &Header{
+			IsLongHeader:     true,
+			Type:             PacketTypeInitial,
+			Version:          protocol.VersionTLS,
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			SrcConnectionID:  protocol.ConnectionID{9, 10, 11, 12},
+			Token:            []byte("foobar"),
+			Length:           1000,
+			PacketNumber:     42,
+			PacketNumberLen:  protocol.PacketNumberLen2,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeLongHeader(b)).To(Succeed())
+
+		r := bytes.NewReader(b.Bytes())
+		parsed, err := parseLongHeader(r)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.Type).To(Equal(PacketTypeInitial))
+		Expect(parsed.Version).To(Equal(protocol.VersionTLS))
+		Expect(parsed.DestConnectionID).To(Equal(hdr.DestConnectionID))
+		Expect(parsed.SrcConnectionID).To(Equal(hdr.SrcConnectionID))
+		Expect(parsed.Token).To(Equal(hdr.Token))
+		Expect(parsed.Length).To(Equal(hdr.Length))
+		Expect(parsed.PacketNumber).To(Equal(hdr.PacketNumber))
+		Expect(r.Len()).To(BeZero())
+	})
+
+	It("rejects an Initial packet whose token length claims more bytes than remain", func() {
+		b := &bytes.Buffer{}
+		b.WriteByte(ietfLongHeaderFlag | ietfLongHeaderFixedBit | uint8(PacketTypeInitial)<<4) // PacketNumberLen bits are 0, irrelevant here
+		Expect(binary.Write(b, binary.BigEndian, uint32(protocol.VersionTLS))).To(Succeed())
+		b.WriteByte(0) // DCIL: no Destination Connection ID
+		b.WriteByte(0) // SCIL: no Source Connection ID
+		utils.WriteVarInt(b, 0x3fffffffffffffff)
+
+		_, err := parseLongHeader(bytes.NewReader(b.Bytes()))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("writes and parses Connection IDs up to the maximum length", func() {
+		destConnID := make(protocol.ConnectionID, protocol.MaxConnectionIDLen)
+		for i := range destConnID {
+			destConnID[i] = byte(i)
+		}
+		hdr := &Header{
+			IsLongHeader:     true,
+			Type:             PacketTypeHandshake,
+			Version:          protocol.VersionTLS,
+			DestConnectionID: destConnID,
+			SrcConnectionID:  protocol.ConnectionID{},
+			Length:           1,
+			PacketNumber:     1,
+			PacketNumberLen:  protocol.PacketNumberLen1,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeLongHeader(b)).To(Succeed())
+
+		parsed, err := parseLongHeader(bytes.NewReader(b.Bytes()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.DestConnectionID).To(Equal(destConnID))
+	})
+
+	It("sets the fixed bit, and rejects a long header packet without it", func() {
+		hdr := &Header{
+			IsLongHeader:     true,
+			Type:             PacketTypeHandshake,
+			Version:          protocol.VersionTLS,
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4},
+			SrcConnectionID:  protocol.ConnectionID{},
+			Length:           1,
+			PacketNumber:     1,
+			PacketNumberLen:  protocol.PacketNumberLen1,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeLongHeader(b)).To(Succeed())
+		Expect(b.Bytes()[0] & ietfLongHeaderFixedBit).ToNot(BeZero())
+
+		raw := b.Bytes()
+		raw[0] &^= ietfLongHeaderFixedBit
+		_, err := parseLongHeader(bytes.NewReader(raw))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects a Connection ID longer than the maximum length", func() {
+		hdr := &Header{
+			IsLongHeader:     true,
+			Type:             PacketTypeHandshake,
+			Version:          protocol.VersionTLS,
+			DestConnectionID: make(protocol.ConnectionID, protocol.MaxConnectionIDLen+1),
+			PacketNumberLen:  protocol.PacketNumberLen1,
+		}
+		Expect(hdr.writeLongHeader(&bytes.Buffer{})).To(HaveOccurred())
+	})
+
+	It("dispatches to the long header parser via ParseHeader", func() {
+		hdr := &Header{
+			IsLongHeader:     true,
+			Type:             PacketTypeHandshake,
+			Version:          protocol.VersionTLS,
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4},
+			SrcConnectionID:  protocol.ConnectionID{},
+			Length:           10,
+			PacketNumber:     7,
+			PacketNumberLen:  protocol.PacketNumberLen1,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeLongHeader(b)).To(Succeed())
+
+		parsed, err := ParseHeader(bytes.NewReader(b.Bytes()), protocol.PerspectiveClient, false, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.IsLongHeader).To(BeTrue())
+		Expect(parsed.Type).To(Equal(PacketTypeHandshake))
+	})
+})
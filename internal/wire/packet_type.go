@@ -0,0 +1,30 @@
+package wire
+
+// PacketType is the type of a packet using the IETF QUIC long header
+type PacketType uint8
+
+const (
+	// PacketTypeInitial is the packet type of an Initial packet
+	PacketTypeInitial PacketType = iota
+	// PacketType0RTT is the packet type of a 0-RTT packet
+	PacketType0RTT
+	// PacketTypeHandshake is the packet type of a Handshake packet
+	PacketTypeHandshake
+	// PacketTypeRetry is the packet type of a Retry packet
+	PacketTypeRetry
+)
+
+func (t PacketType) String() string {
+	switch t {
+	case PacketTypeInitial:
+		return "Initial"
+	case PacketType0RTT:
+		return "0-RTT"
+	case PacketTypeHandshake:
+		return "Handshake"
+	case PacketTypeRetry:
+		return "Retry"
+	default:
+		return "unknown packet type"
+	}
+}
@@ -0,0 +1,306 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/utils"
+	"github.com/lucas-clemente/quic-go/qerr"
+)
+
+// the gQUIC public flags, as defined by the wire format of the Public Header
+const (
+	publicFlagVersion      = 0x01
+	publicFlagReset        = 0x02
+	publicFlagNonce        = 0x04
+	publicFlagConnectionID = 0x08
+)
+
+var (
+	errReceivedOmittedConnectionID = errors.New("PublicHeader: receiving packets with omitted ConnectionID is not supported")
+	errInvalidConnectionID         = errors.New("PublicHeader: invalid ConnectionID")
+	errInvalidPacketNumberLen      = errors.New("PublicHeader: invalid packet number length")
+)
+
+// versionBigEndian is an arbitrary non-zero version number, used in tests
+// that only care about the wire length of the Version field, not its value.
+var versionBigEndian protocol.VersionNumber = 0x0a0a0a0a
+
+// Header is the header of a gQUIC or IETF QUIC packet.
+// Not all fields are set for every Header type.
+type Header struct {
+	ResetFlag   bool
+	VersionFlag bool
+
+	// IsVersionNegotiation is set when this is the server's reply that lists
+	// the versions it supports, rather than a Header carrying a single Version.
+	IsVersionNegotiation bool
+
+	DestConnectionID protocol.ConnectionID
+	SrcConnectionID  protocol.ConnectionID
+	OmitConnectionID bool
+
+	Version           protocol.VersionNumber
+	SupportedVersions []protocol.VersionNumber
+
+	DiversificationNonce []byte
+
+	// the following fields are only set for packets using the IETF QUIC
+	// long header
+	IsLongHeader bool
+	Type         PacketType
+	Token        []byte
+	// OriginalDestConnectionID is only set for Retry packets: it's the
+	// Destination Connection ID the client used in the Initial packet that
+	// triggered this Retry.
+	OriginalDestConnectionID protocol.ConnectionID
+	// Length is the length of the remainder of the packet (after the
+	// Length field itself), i.e. the packet number plus the payload
+	Length protocol.ByteCount
+
+	// the following fields are only set for packets using the IETF QUIC
+	// short header
+	KeyPhase bool
+	SpinBit  bool
+
+	// PacketNumber and PacketNumberLen are filled in by the long/short
+	// header parsers. The gQUIC Public Header parser instead returns these
+	// separately from readPublicHeaderPacketNumber, since packet number
+	// decoding there happens only after the payload has been decrypted.
+	PacketNumber    protocol.PacketNumber
+	PacketNumberLen protocol.PacketNumberLen
+}
+
+// parsePublicHeader parses a gQUIC Public Header whose Connection ID has the
+// default, fixed length gQUIC has always used. See
+// parsePublicHeaderWithConnIDLen for headers using a different length.
+func parsePublicHeader(b *bytes.Reader, perspective protocol.Perspective) (*Header, error) {
+	return parsePublicHeaderWithConnIDLen(b, perspective, protocol.DefaultConnectionIDLength)
+}
+
+// parsePublicHeaderWithConnIDLen parses a gQUIC Public Header whose
+// Connection ID is connIDLen bytes long. Unlike the IETF long header, the
+// Public Header carries no DCIL/SCIL field of its own, so a length must be
+// supplied out of band by the caller - typically from the
+// ConnectionIDGenerator that minted it - exactly as parseShortHeader already
+// requires for IETF short headers.
+//
+// The perspective parameter must be the perspective of the endpoint that
+// originally sent out this header. It is used to decide whether omitting
+// the Connection ID and sending a Version Negotiation list is legal: only
+// the server may omit the Connection ID, and only the server sends out a
+// list of supported versions.
+func parsePublicHeaderWithConnIDLen(b *bytes.Reader, perspective protocol.Perspective, connIDLen int) (*Header, error) {
+	if err := validateConnectionIDLen(connIDLen); err != nil {
+		return nil, err
+	}
+	publicFlagByte, err := b.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	hdr := &Header{
+		ResetFlag:   publicFlagByte&publicFlagReset != 0,
+		VersionFlag: publicFlagByte&publicFlagVersion != 0,
+	}
+
+	if publicFlagByte&publicFlagConnectionID != 0 {
+		connID := make(protocol.ConnectionID, connIDLen)
+		if _, err := io.ReadFull(b, connID); err != nil {
+			return nil, err
+		}
+		if connIDLen > 0 && connID.Equal(make(protocol.ConnectionID, connIDLen)) {
+			return nil, errInvalidConnectionID
+		}
+		hdr.DestConnectionID = connID
+		hdr.SrcConnectionID = connID
+	} else {
+		if perspective == protocol.PerspectiveClient {
+			return nil, errReceivedOmittedConnectionID
+		}
+		hdr.OmitConnectionID = true
+	}
+
+	if publicFlagByte&publicFlagNonce != 0 && !hdr.VersionFlag {
+		hdr.DiversificationNonce = make([]byte, 32)
+		if _, err := io.ReadFull(b, hdr.DiversificationNonce); err != nil {
+			return nil, err
+		}
+	}
+
+	if hdr.VersionFlag {
+		// A server never just sends a single version; it sends the list of
+		// versions it supports, so that the client can pick one.
+		if perspective == protocol.PerspectiveServer {
+			hdr.IsVersionNegotiation = true
+			if b.Len() == 0 {
+				return nil, qerr.Error(qerr.InvalidVersionNegotiationPacket, "empty version list")
+			}
+			if b.Len()%4 != 0 {
+				return nil, qerr.InvalidVersionNegotiationPacket
+			}
+			for b.Len() > 0 {
+				var v uint32
+				if err := binary.Read(b, binary.BigEndian, &v); err != nil {
+					return nil, err
+				}
+				hdr.SupportedVersions = append(hdr.SupportedVersions, protocol.VersionNumber(v))
+			}
+			return hdr, nil
+		}
+		var v uint32
+		if err := binary.Read(b, binary.BigEndian, &v); err != nil {
+			return nil, err
+		}
+		hdr.Version = protocol.VersionNumber(v)
+	}
+
+	return hdr, nil
+}
+
+// readPublicHeaderPacketNumber reads the packet number from a gQUIC packet,
+// after the rest of the Public Header has already been consumed.
+func readPublicHeaderPacketNumber(b *bytes.Reader, publicFlagByte byte) (protocol.PacketNumber, protocol.PacketNumberLen, error) {
+	packetNumberLen := protocol.PacketNumberLen1
+	switch (publicFlagByte & 0x30) >> 4 {
+	case 0x1:
+		packetNumberLen = protocol.PacketNumberLen2
+	case 0x2:
+		packetNumberLen = protocol.PacketNumberLen4
+	case 0x3:
+		return 0, 0, errInvalidPacketNumberLen
+	}
+	pn, err := readPacketNumber(b, packetNumberLen)
+	if err != nil {
+		return 0, 0, err
+	}
+	return pn, packetNumberLen, nil
+}
+
+// readPacketNumber reads a big-endian packet number of the given length.
+// It's shared by the gQUIC Public Header and the IETF QUIC long/short
+// headers, which all encode the packet number the same way.
+func readPacketNumber(b *bytes.Reader, length protocol.PacketNumberLen) (protocol.PacketNumber, error) {
+	var pn uint64
+	for i := 0; i < int(length); i++ {
+		byt, err := b.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		pn = pn<<8 | uint64(byt)
+	}
+	return protocol.PacketNumber(pn), nil
+}
+
+// writePacketNumber writes a big-endian packet number of the given length.
+// It's shared by the gQUIC Public Header and the IETF QUIC long/short
+// headers, which all encode the packet number the same way.
+func writePacketNumber(b *bytes.Buffer, pn protocol.PacketNumber, length protocol.PacketNumberLen) error {
+	if length < protocol.PacketNumberLen1 || length > protocol.PacketNumberLen4 {
+		return errInvalidPacketNumberLen
+	}
+	for i := int(length) - 1; i >= 0; i-- {
+		b.WriteByte(uint8(pn >> uint(8*i)))
+	}
+	return nil
+}
+
+// writePublicHeader writes a gQUIC Public Header.
+func (h *Header) writePublicHeader(b *bytes.Buffer, packetNumber protocol.PacketNumber, packetNumberLen protocol.PacketNumberLen, perspective protocol.Perspective) error {
+	if h.ResetFlag {
+		return errors.New("PublicHeader: Writing of Public Reset Packets not supported")
+	}
+	if h.VersionFlag && h.Version == protocol.VersionWhatever {
+		return errors.New("PublicHeader: Writing of Version Negotiation Packets not supported")
+	}
+	if !h.OmitConnectionID && !h.DestConnectionID.Equal(h.SrcConnectionID) {
+		return errors.New("PublicHeader: SrcConnectionID must be equal to DestConnectionID")
+	}
+	if !h.OmitConnectionID {
+		if err := validateConnectionIDLen(len(h.DestConnectionID)); err != nil {
+			return fmt.Errorf("PublicHeader: %w", err)
+		}
+	}
+
+	publicFlagByte := uint8(0x00)
+	if !h.OmitConnectionID {
+		publicFlagByte |= publicFlagConnectionID
+	}
+	if h.VersionFlag {
+		publicFlagByte |= publicFlagVersion
+	}
+	if len(h.DiversificationNonce) > 0 {
+		publicFlagByte |= publicFlagNonce
+	}
+	switch packetNumberLen {
+	case protocol.PacketNumberLen2:
+		publicFlagByte |= 0x1 << 4
+	case protocol.PacketNumberLen4:
+		publicFlagByte |= 0x2 << 4
+	}
+	b.WriteByte(publicFlagByte)
+
+	if !h.OmitConnectionID {
+		b.Write(h.DestConnectionID)
+	}
+
+	if len(h.DiversificationNonce) > 0 {
+		b.Write(h.DiversificationNonce)
+	}
+
+	if h.VersionFlag {
+		if err := binary.Write(b, binary.BigEndian, uint32(h.Version)); err != nil {
+			return err
+		}
+	}
+
+	if packetNumberLen != protocol.PacketNumberLen1 && packetNumberLen != protocol.PacketNumberLen2 && packetNumberLen != protocol.PacketNumberLen4 {
+		return errInvalidPacketNumberLen
+	}
+	return writePacketNumber(b, packetNumber, packetNumberLen)
+}
+
+// getPublicHeaderLength determines the length of the Public Header, in bytes
+func (h *Header) getPublicHeaderLength(packetNumberLen protocol.PacketNumberLen, perspective protocol.Perspective) protocol.ByteCount {
+	length := protocol.ByteCount(1) // 1 byte for the public flag byte
+	if !h.OmitConnectionID {
+		length += protocol.ByteCount(len(h.DestConnectionID))
+	}
+	length += protocol.ByteCount(len(h.DiversificationNonce))
+	if h.VersionFlag {
+		length += 4
+	}
+	length += protocol.ByteCount(packetNumberLen)
+	return length
+}
+
+// logPublicHeader logs the Public Header, for debugging purposes
+func (h *Header) logPublicHeader(logger utils.Logger) {
+	connID := "(empty)"
+	if !h.OmitConnectionID {
+		connID = h.DestConnectionID.String()
+	}
+	versionStr := "(unset)"
+	if h.Version != 0 {
+		versionStr = h.Version.String()
+	}
+	logger.Debugf("Public Header{ConnectionID: %s, Version: %s, ResetFlag: %t, VersionFlag: %t}", connID, versionStr, h.ResetFlag, h.VersionFlag)
+	if len(h.DiversificationNonce) > 0 {
+		logger.Debugf("\tDiversificationNonce: %#v", h.DiversificationNonce)
+	}
+}
+
+// ComposeGQUICVersionNegotiation composes a Version Negotiation Packet, as
+// sent by gQUIC servers that don't support the version requested by the client
+func ComposeGQUICVersionNegotiation(connID protocol.ConnectionID, versions []protocol.VersionNumber) []byte {
+	fullReply := &bytes.Buffer{}
+	fullReply.WriteByte(publicFlagVersion | publicFlagConnectionID)
+	fullReply.Write(connID)
+	for _, v := range versions {
+		binary.Write(fullReply, binary.BigEndian, uint32(v))
+	}
+	return fullReply.Bytes()
+}
@@ -61,6 +61,23 @@ var _ = Describe("Public Header", func() {
 			Expect(err).To(MatchError(errInvalidConnectionID))
 		})
 
+		It("parses a Public Header with a non-default Connection ID length", func() {
+			connID := protocol.ConnectionID{1, 2, 3, 4, 5}
+			b := &bytes.Buffer{}
+			b.WriteByte(0x08)
+			b.Write(connID)
+			hdr, err := parsePublicHeaderWithConnIDLen(bytes.NewReader(b.Bytes()), protocol.PerspectiveServer, len(connID))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hdr.DestConnectionID).To(Equal(connID))
+			Expect(hdr.SrcConnectionID).To(Equal(connID))
+		})
+
+		It("rejects a Connection ID length above the maximum", func() {
+			b := bytes.NewReader([]byte{0x08})
+			_, err := parsePublicHeaderWithConnIDLen(b, protocol.PerspectiveServer, protocol.MaxConnectionIDLen+1)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("parses a PUBLIC_RESET packet", func() {
 			b := bytes.NewReader([]byte{0xa, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8})
 			hdr, err := parsePublicHeader(b, protocol.PerspectiveServer)
@@ -220,7 +237,7 @@ var _ = Describe("Public Header", func() {
 			Expect(err).To(MatchError("PublicHeader: SrcConnectionID must be equal to DestConnectionID"))
 		})
 
-		It("refuses to write a Public Header if the connection ID has the wrong length", func() {
+		It("writes a Public Header whose connection ID isn't the default 8 bytes", func() {
 			connID := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7}
 			hdr := Header{
 				DestConnectionID: connID,
@@ -228,7 +245,19 @@ var _ = Describe("Public Header", func() {
 			}
 			b := &bytes.Buffer{}
 			err := hdr.writePublicHeader(b, 1, protocol.PacketNumberLen1, protocol.PerspectiveServer)
-			Expect(err).To(MatchError("PublicHeader: wrong length for Connection ID: 7 (expected 8)"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(b.Bytes()[1:8]).To(Equal([]byte(connID)))
+		})
+
+		It("refuses to write a Public Header if the connection ID is longer than the maximum length", func() {
+			connID := make(protocol.ConnectionID, protocol.MaxConnectionIDLen+1)
+			hdr := Header{
+				DestConnectionID: connID,
+				SrcConnectionID:  connID,
+			}
+			b := &bytes.Buffer{}
+			err := hdr.writePublicHeader(b, 1, protocol.PacketNumberLen1, protocol.PerspectiveServer)
+			Expect(err).To(HaveOccurred())
 		})
 
 		It("refuses to write a Public Header with a 6 byte number length", func() {
@@ -333,6 +362,8 @@ var _ = Describe("Public Header", func() {
 
 			It("works with diversification nonce", func() {
 				hdr := Header{
+					DestConnectionID:     connID,
+					SrcConnectionID:      connID,
 					DiversificationNonce: []byte("foo"),
 				}
 				length := hdr.getPublicHeaderLength(protocol.PacketNumberLen4, protocol.PerspectiveServer)
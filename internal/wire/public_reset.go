@@ -0,0 +1,197 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// the address families used in the CADR tag's ClientAddress, as defined by
+// the gQUIC Public Reset wire format
+const (
+	addressFamilyIPv4 uint16 = 2
+	addressFamilyIPv6 uint16 = 10
+)
+
+// the tags used in a Public Reset's tagged message, as defined by the gQUIC
+// Public Reset wire format
+const (
+	tagPRST = "PRST"
+	tagRNON = "RNON"
+	tagRSEQ = "RSEQ"
+	tagCADR = "CADR"
+)
+
+var errInvalidPublicResetPacket = errors.New("wire: invalid public reset packet")
+
+// A PublicReset is a gQUIC Public Reset packet. A server (or an attacker
+// spoofing one) sends this instead of further data once it has lost all
+// state for a connection, so that the client can tear the connection down
+// immediately instead of waiting for a timeout.
+type PublicReset struct {
+	// RejectedPacketNumber is the packet number of the packet that caused
+	// the sender to send this Public Reset.
+	RejectedPacketNumber protocol.PacketNumber
+	// Nonce is the nonce proof carried in the RNON tag. ParsePublicReset
+	// only decodes it; it doesn't check it against anything, so a parsed
+	// Public Reset is still unauthenticated.
+	Nonce uint64
+	// ClientAddress is the address the sender observed this connection's
+	// packets arriving from, if it chose to include one (the CADR tag).
+	// It's informational only: the client doesn't need it to validate the
+	// reset, which is why WritePublicReset still produces a usable Public
+	// Reset when addr is nil.
+	ClientAddress *net.UDPAddr
+}
+
+// WritePublicReset writes a gQUIC Public Reset packet for the connection
+// identified by connID, rejecting rejectedPacketNumber and proving
+// ownership of the connection with nonceProof. If addr is a *net.UDPAddr,
+// it's included as the CADR tag, informing the client of the address the
+// rejected packet appeared to come from; pass nil to omit it.
+func WritePublicReset(connID protocol.ConnectionID, rejectedPacketNumber protocol.PacketNumber, nonceProof uint64, addr net.Addr) []byte {
+	b := &bytes.Buffer{}
+	b.WriteByte(publicFlagReset | publicFlagConnectionID)
+	b.Write(connID)
+
+	rnon := make([]byte, 8)
+	binary.LittleEndian.PutUint64(rnon, nonceProof)
+	rseq := make([]byte, 8)
+	binary.LittleEndian.PutUint64(rseq, uint64(rejectedPacketNumber))
+
+	tags := []string{tagRNON, tagRSEQ}
+	values := [][]byte{rnon, rseq}
+	if cadr := encodeCADR(addr); cadr != nil {
+		tags = append(tags, tagCADR)
+		values = append(values, cadr)
+	}
+
+	b.WriteString(tagPRST)
+	binary.Write(b, binary.LittleEndian, uint32(len(tags)))
+	var offset uint32
+	for i, tag := range tags {
+		offset += uint32(len(values[i]))
+		b.WriteString(tag)
+		binary.Write(b, binary.LittleEndian, offset)
+	}
+	for _, value := range values {
+		b.Write(value)
+	}
+	return b.Bytes()
+}
+
+// encodeCADR encodes addr as a gQUIC ClientAddress (the CADR tag's value):
+// a 2-byte address family, the raw IP bytes, and a 2-byte port, all
+// little-endian. It returns nil if addr isn't a *net.UDPAddr, so that
+// callers without a meaningful address can omit the tag entirely.
+func encodeCADR(addr net.Addr) []byte {
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok || udpAddr == nil {
+		return nil
+	}
+	family := addressFamilyIPv6
+	ip := udpAddr.IP.To16()
+	if ip4 := udpAddr.IP.To4(); ip4 != nil {
+		family = addressFamilyIPv4
+		ip = ip4
+	}
+	b := &bytes.Buffer{}
+	binary.Write(b, binary.LittleEndian, family)
+	b.Write(ip)
+	binary.Write(b, binary.LittleEndian, uint16(udpAddr.Port))
+	return b.Bytes()
+}
+
+// decodeCADR decodes a gQUIC ClientAddress, as encoded by encodeCADR.
+func decodeCADR(value []byte) (*net.UDPAddr, error) {
+	if len(value) < 2 {
+		return nil, errInvalidPublicResetPacket
+	}
+	family := binary.LittleEndian.Uint16(value)
+	ipLen := 4
+	if family == addressFamilyIPv6 {
+		ipLen = 16
+	}
+	if len(value) != 2+ipLen+2 {
+		return nil, errInvalidPublicResetPacket
+	}
+	ip := net.IP(value[2 : 2+ipLen])
+	port := binary.LittleEndian.Uint16(value[2+ipLen:])
+	return &net.UDPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// ParsePublicReset parses the tagged message of a gQUIC Public Reset packet.
+// The caller is expected to already have consumed the Public Header (the
+// flag byte and the Connection ID) via parsePublicHeader.
+func ParsePublicReset(b *bytes.Reader) (*PublicReset, error) {
+	msgTag := make([]byte, 4)
+	if _, err := io.ReadFull(b, msgTag); err != nil {
+		return nil, errInvalidPublicResetPacket
+	}
+	if string(msgTag) != tagPRST {
+		return nil, errInvalidPublicResetPacket
+	}
+
+	var numTags uint32
+	if err := binary.Read(b, binary.LittleEndian, &numTags); err != nil {
+		return nil, errInvalidPublicResetPacket
+	}
+	// numTags is attacker-controlled; each tag entry takes 8 bytes on the
+	// wire (a 4-byte tag plus a 4-byte offset), so without this check a
+	// spoofed numTags near the uint32 max would drive make() below into a
+	// multi-gigabyte allocation for an 8-byte packet.
+	if uint64(numTags)*8 > uint64(b.Len()) {
+		return nil, errInvalidPublicResetPacket
+	}
+
+	tags := make([]string, numTags)
+	endOffsets := make([]uint32, numTags)
+	for i := range tags {
+		tag := make([]byte, 4)
+		if _, err := io.ReadFull(b, tag); err != nil {
+			return nil, errInvalidPublicResetPacket
+		}
+		var offset uint32
+		if err := binary.Read(b, binary.LittleEndian, &offset); err != nil {
+			return nil, errInvalidPublicResetPacket
+		}
+		tags[i] = string(tag)
+		endOffsets[i] = offset
+	}
+
+	pr := &PublicReset{}
+	var start uint32
+	for i, tag := range tags {
+		if endOffsets[i] < start {
+			return nil, errInvalidPublicResetPacket
+		}
+		value := make([]byte, endOffsets[i]-start)
+		if _, err := io.ReadFull(b, value); err != nil {
+			return nil, errInvalidPublicResetPacket
+		}
+		switch tag {
+		case tagRNON:
+			if len(value) != 8 {
+				return nil, errInvalidPublicResetPacket
+			}
+			pr.Nonce = binary.LittleEndian.Uint64(value)
+		case tagRSEQ:
+			if len(value) != 8 {
+				return nil, errInvalidPublicResetPacket
+			}
+			pr.RejectedPacketNumber = protocol.PacketNumber(binary.LittleEndian.Uint64(value))
+		case tagCADR:
+			addr, err := decodeCADR(value)
+			if err != nil {
+				return nil, err
+			}
+			pr.ClientAddress = addr
+		}
+		start = endOffsets[i]
+	}
+	return pr, nil
+}
@@ -0,0 +1,78 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Public Reset", func() {
+	It("writes and parses a Public Reset without a client address", func() {
+		connID := protocol.ConnectionID{0x13, 0x37, 0, 0, 0xde, 0xca, 0xfb, 0xad}
+		data := WritePublicReset(connID, 0x1337, 0xdeadbeefcafe, nil)
+
+		b := bytes.NewReader(data)
+		hdr, err := parsePublicHeader(b, protocol.PerspectiveServer)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.ResetFlag).To(BeTrue())
+		Expect(hdr.DestConnectionID).To(Equal(connID))
+
+		pr, err := ParsePublicReset(b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pr.RejectedPacketNumber).To(Equal(protocol.PacketNumber(0x1337)))
+		Expect(pr.Nonce).To(Equal(uint64(0xdeadbeefcafe)))
+		Expect(pr.ClientAddress).To(BeNil())
+	})
+
+	It("writes and parses a Public Reset with an IPv4 client address (CADR)", func() {
+		connID := protocol.ConnectionID{0x13, 0x37, 0, 0, 0xde, 0xca, 0xfb, 0xad}
+		addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4242}
+		data := WritePublicReset(connID, 0x1337, 0xdeadbeefcafe, addr)
+
+		b := bytes.NewReader(data)
+		_, err := parsePublicHeader(b, protocol.PerspectiveServer)
+		Expect(err).ToNot(HaveOccurred())
+
+		pr, err := ParsePublicReset(b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pr.ClientAddress).ToNot(BeNil())
+		Expect(pr.ClientAddress.IP.Equal(addr.IP)).To(BeTrue())
+		Expect(pr.ClientAddress.Port).To(Equal(addr.Port))
+	})
+
+	It("writes and parses a Public Reset with an IPv6 client address (CADR)", func() {
+		connID := protocol.ConnectionID{0x13, 0x37, 0, 0, 0xde, 0xca, 0xfb, 0xad}
+		addr := &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 4242}
+		data := WritePublicReset(connID, 0x1337, 0xdeadbeefcafe, addr)
+
+		b := bytes.NewReader(data)
+		_, err := parsePublicHeader(b, protocol.PerspectiveServer)
+		Expect(err).ToNot(HaveOccurred())
+
+		pr, err := ParsePublicReset(b)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pr.ClientAddress).ToNot(BeNil())
+		Expect(pr.ClientAddress.IP.Equal(addr.IP)).To(BeTrue())
+		Expect(pr.ClientAddress.Port).To(Equal(addr.Port))
+	})
+
+	It("errors on a message without the PRST tag", func() {
+		b := bytes.NewReader([]byte("notatag"))
+		_, err := ParsePublicReset(b)
+		Expect(err).To(MatchError(errInvalidPublicResetPacket))
+	})
+
+	It("rejects a numTags claiming more tag entries than remain in the packet", func() {
+		buf := &bytes.Buffer{}
+		buf.WriteString(tagPRST)
+		Expect(binary.Write(buf, binary.LittleEndian, uint32(0xffffffff))).To(Succeed())
+
+		_, err := ParsePublicReset(bytes.NewReader(buf.Bytes()))
+		Expect(err).To(MatchError(errInvalidPublicResetPacket))
+	})
+})
@@ -0,0 +1,32 @@
+package wire
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// ComposeRetry composes an IETF QUIC Retry packet. origDestConnID is the
+// Destination Connection ID of the Initial packet that triggered this
+// Retry; it's carried in the Retry so the client can tie it back to its
+// original attempt. srcConnID is the new Connection ID the server picked
+// for this connection, and destConnID is the client's Connection ID
+// (copied from the triggering Initial's Source Connection ID). token is
+// the address-validation token the client must echo back in its retried
+// Initial, typically minted by a TokenGenerator.
+func ComposeRetry(origDestConnID, srcConnID, destConnID protocol.ConnectionID, token []byte) ([]byte, error) {
+	hdr := &Header{
+		IsLongHeader:             true,
+		Type:                     PacketTypeRetry,
+		Version:                  protocol.VersionTLS,
+		DestConnectionID:         destConnID,
+		SrcConnectionID:          srcConnID,
+		OriginalDestConnectionID: origDestConnID,
+		Token:                    token,
+	}
+	b := &bytes.Buffer{}
+	if err := hdr.writeLongHeader(b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,30 @@
+package wire
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry", func() {
+	It("composes and parses a Retry packet", func() {
+		origDestConnID := protocol.ConnectionID{0xde, 0xad, 0xbe, 0xef}
+		srcConnID := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}
+		destConnID := protocol.ConnectionID{8, 7, 6, 5}
+		token := []byte("this is a retry token")
+
+		data, err := ComposeRetry(origDestConnID, srcConnID, destConnID, token)
+		Expect(err).ToNot(HaveOccurred())
+
+		hdr, err := parseLongHeader(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.Type).To(Equal(PacketTypeRetry))
+		Expect(hdr.SrcConnectionID).To(Equal(srcConnID))
+		Expect(hdr.DestConnectionID).To(Equal(destConnID))
+		Expect(hdr.OriginalDestConnectionID).To(Equal(origDestConnID))
+		Expect(hdr.Token).To(Equal(token))
+	})
+})
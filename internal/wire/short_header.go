@@ -0,0 +1,59 @@
+package wire
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// ietfShortHeaderFixedBit must always be set on short header packets. It
+// lets endpoints distinguish a (possibly future) greased short header from
+// garbage, the same way the long header's ietfLongHeaderFixedBit does.
+const ietfShortHeaderFixedBit = 0x40
+
+// parseShortHeader parses the short header (1-RTT) of an IETF QUIC packet.
+// Unlike the long header, the short header doesn't carry a Connection ID
+// length on the wire, so the caller has to supply connIDLen: it's expected
+// to know the length it handed out, e.g. via a ConnectionIDGenerator.
+func parseShortHeader(b *bytes.Reader, connIDLen int) (*Header, error) {
+	firstByte, err := b.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	h := &Header{
+		SpinBit:         firstByte&0x20 != 0,
+		KeyPhase:        firstByte&0x4 != 0,
+		PacketNumberLen: protocol.PacketNumberLen(firstByte&0x3) + 1,
+	}
+
+	h.DestConnectionID = make(protocol.ConnectionID, connIDLen)
+	if _, err := io.ReadFull(b, h.DestConnectionID); err != nil {
+		return nil, err
+	}
+
+	pn, err := readPacketNumber(b, h.PacketNumberLen)
+	if err != nil {
+		return nil, err
+	}
+	h.PacketNumber = pn
+
+	return h, nil
+}
+
+// writeShortHeader writes the short header (1-RTT) of an IETF QUIC packet.
+func (h *Header) writeShortHeader(b *bytes.Buffer) error {
+	if h.PacketNumberLen < protocol.PacketNumberLen1 || h.PacketNumberLen > protocol.PacketNumberLen4 {
+		return errInvalidPacketNumberLen
+	}
+	firstByte := uint8(ietfShortHeaderFixedBit) | uint8(h.PacketNumberLen-1)
+	if h.SpinBit {
+		firstByte |= 0x20
+	}
+	if h.KeyPhase {
+		firstByte |= 0x4
+	}
+	b.WriteByte(firstByte)
+	b.Write(h.DestConnectionID)
+	return writePacketNumber(b, h.PacketNumber, h.PacketNumberLen)
+}
@@ -0,0 +1,84 @@
+package wire
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Short Header", func() {
+	It("writes and parses a short header packet", func() {
+		hdr := &Header{
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			KeyPhase:         true,
+			SpinBit:          true,
+			PacketNumber:     1337,
+			PacketNumberLen:  protocol.PacketNumberLen2,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeShortHeader(b)).To(Succeed())
+
+		r := bytes.NewReader(b.Bytes())
+		parsed, err := parseShortHeader(r, 8)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.DestConnectionID).To(Equal(hdr.DestConnectionID))
+		Expect(parsed.KeyPhase).To(BeTrue())
+		Expect(parsed.SpinBit).To(BeTrue())
+		Expect(parsed.PacketNumber).To(Equal(hdr.PacketNumber))
+		Expect(r.Len()).To(BeZero())
+	})
+
+	It("dispatches to the short header parser via ParseHeader", func() {
+		hdr := &Header{
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4},
+			PacketNumber:     5,
+			PacketNumberLen:  protocol.PacketNumberLen1,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeShortHeader(b)).To(Succeed())
+
+		parsed, err := ParseHeader(bytes.NewReader(b.Bytes()), protocol.PerspectiveServer, true, 4)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.IsLongHeader).To(BeFalse())
+		Expect(parsed.DestConnectionID).To(Equal(hdr.DestConnectionID))
+	})
+
+	It("still dispatches gQUIC packets when shortHeaderIsIETF is false", func() {
+		connID := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}
+		b := &bytes.Buffer{}
+		hdr := &Header{DestConnectionID: connID, SrcConnectionID: connID}
+		Expect(hdr.writePublicHeader(b, 1, protocol.PacketNumberLen1, protocol.PerspectiveServer)).To(Succeed())
+
+		parsed, err := ParseHeader(bytes.NewReader(b.Bytes()), protocol.PerspectiveClient, false, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.IsLongHeader).To(BeFalse())
+		Expect(parsed.DestConnectionID).To(Equal(connID))
+	})
+
+	It("derives the short header dispatch from a known Version via ParseHeaderForVersion", func() {
+		connID := protocol.ConnectionID{1, 2, 3, 4}
+		hdr := &Header{DestConnectionID: connID, PacketNumber: 5, PacketNumberLen: protocol.PacketNumberLen1}
+		b := &bytes.Buffer{}
+		Expect(hdr.writeShortHeader(b)).To(Succeed())
+
+		parsed, err := ParseHeaderForVersion(bytes.NewReader(b.Bytes()), protocol.PerspectiveServer, protocol.VersionTLS, 4)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.IsLongHeader).To(BeFalse())
+		Expect(parsed.DestConnectionID).To(Equal(connID))
+	})
+
+	It("derives the gQUIC dispatch from a known Version via ParseHeaderForVersion", func() {
+		connID := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}
+		b := &bytes.Buffer{}
+		hdr := &Header{DestConnectionID: connID, SrcConnectionID: connID}
+		Expect(hdr.writePublicHeader(b, 1, protocol.PacketNumberLen1, protocol.PerspectiveServer)).To(Succeed())
+
+		parsed, err := ParseHeaderForVersion(bytes.NewReader(b.Bytes()), protocol.PerspectiveClient, protocol.Version39, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.IsLongHeader).To(BeFalse())
+		Expect(parsed.DestConnectionID).To(Equal(connID))
+	})
+})
@@ -0,0 +1,58 @@
+package wire
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// A Tracer receives structured events about the packet headers this package
+// parses and writes. It's the qlog-style replacement for the old printf-based
+// logPublicHeader: where that method only ever formatted a Header onto a
+// utils.Logger, a Tracer can additionally feed a qlog file for visualization
+// in qvis, or any other sink that's more useful than a plain text log when
+// hunting a stalled-connection bug.
+type Tracer interface {
+	// ReceivedPacket is called once a Header has been parsed successfully.
+	// size is the number of bytes the full, on-the-wire packet occupied.
+	ReceivedPacket(hdr *Header, size protocol.ByteCount)
+	// SentPacket is called once a Header has been written successfully.
+	// size is the number of bytes the header itself occupied.
+	SentPacket(hdr *Header, size protocol.ByteCount)
+	// DroppedPacket is called when a packet couldn't be parsed. reason is a
+	// short, human-readable explanation, typically the parse error's text.
+	DroppedPacket(reason string)
+	// NegotiatedVersion is called once a Version Negotiation exchange has
+	// settled on chosen, out of the versions the two ends offered.
+	NegotiatedVersion(chosen protocol.VersionNumber, clientVersions, serverVersions []protocol.VersionNumber)
+}
+
+// ParseHeaderTraced parses a packet header exactly like ParseHeader, and
+// additionally reports the outcome to tracer, if set. ParseHeader itself
+// stays tracer-agnostic, so callers that don't care about tracing (like this
+// package's own tests) can keep calling it directly.
+func ParseHeaderTraced(tracer Tracer, b *bytes.Reader, perspective protocol.Perspective, shortHeaderIsIETF bool, shortHeaderConnIDLen int, size protocol.ByteCount) (*Header, error) {
+	hdr, err := ParseHeader(b, perspective, shortHeaderIsIETF, shortHeaderConnIDLen)
+	if tracer == nil {
+		return hdr, err
+	}
+	if err != nil {
+		tracer.DroppedPacket(err.Error())
+		return hdr, err
+	}
+	tracer.ReceivedPacket(hdr, size)
+	return hdr, nil
+}
+
+// WritePublicHeaderTraced writes a gQUIC Public Header exactly like
+// writePublicHeader, and additionally reports the outcome to tracer, if set.
+func (h *Header) WritePublicHeaderTraced(tracer Tracer, b *bytes.Buffer, packetNumber protocol.PacketNumber, packetNumberLen protocol.PacketNumberLen, perspective protocol.Perspective) error {
+	before := b.Len()
+	if err := h.writePublicHeader(b, packetNumber, packetNumberLen, perspective); err != nil {
+		return err
+	}
+	if tracer != nil {
+		tracer.SentPacket(h, protocol.ByteCount(b.Len()-before))
+	}
+	return nil
+}
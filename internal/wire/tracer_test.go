@@ -0,0 +1,65 @@
+package wire
+
+import (
+	"bytes"
+	"log"
+	"os"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracer", func() {
+	var (
+		buf    *bytes.Buffer
+		tracer *LoggingTracer
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		logger := utils.DefaultLogger
+		logger.SetLogLevel(utils.LogLevelDebug)
+		log.SetOutput(buf)
+		tracer = &LoggingTracer{Logger: logger}
+	})
+
+	AfterEach(func() {
+		log.SetOutput(os.Stdout)
+	})
+
+	It("reports a successfully parsed packet", func() {
+		hdr := &Header{
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			SrcConnectionID:  protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			Version:          protocol.Version39,
+			VersionFlag:      true,
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.writePublicHeader(b, 1, protocol.PacketNumberLen1, protocol.PerspectiveClient)).To(Succeed())
+
+		parsed, err := ParseHeaderTraced(tracer, bytes.NewReader(b.Bytes()), protocol.PerspectiveClient, false, 0, protocol.ByteCount(b.Len()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.Version).To(Equal(protocol.Version39))
+		Expect(buf.String()).To(ContainSubstring("Public Header{ConnectionID"))
+		Expect(buf.String()).To(ContainSubstring("size: 14 bytes"))
+	})
+
+	It("reports a dropped packet", func() {
+		_, err := ParseHeaderTraced(tracer, bytes.NewReader(nil), protocol.PerspectiveServer, false, 0, 0)
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("dropped packet"))
+	})
+
+	It("reports a successfully written packet", func() {
+		hdr := &Header{
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			SrcConnectionID:  protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		b := &bytes.Buffer{}
+		Expect(hdr.WritePublicHeaderTraced(tracer, b, 1, protocol.PacketNumberLen1, protocol.PerspectiveServer)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("Public Header{ConnectionID"))
+	})
+})
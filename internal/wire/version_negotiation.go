@@ -0,0 +1,136 @@
+package wire
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+)
+
+// VersionFamily selects which Version Negotiation wire form
+// WriteVersionNegotiation emits.
+type VersionFamily int
+
+const (
+	// VersionFamilyGQUIC emits a gQUIC-style Version Negotiation Packet.
+	VersionFamilyGQUIC VersionFamily = iota
+	// VersionFamilyIETF emits an IETF QUIC long header Version Negotiation
+	// Packet (RFC 9000, Section 17.2.1).
+	VersionFamilyIETF
+)
+
+var errInvalidVersionFamily = errors.New("wire: unknown version family")
+
+// WriteVersionNegotiation composes a Version Negotiation Packet offering
+// versions, plus a greased reserved version so that peers exercise their
+// unknown-version handling instead of coming to depend on the exact set of
+// versions this server happens to support today. dest and src are both used
+// by the IETF form; the gQUIC form only ever had a single Connection ID, so
+// only dest is used for it.
+func WriteVersionNegotiation(family VersionFamily, dest, src protocol.ConnectionID, versions []protocol.VersionNumber) ([]byte, error) {
+	greased := append(append([]protocol.VersionNumber{}, versions...), protocol.GreaseVersion())
+	switch family {
+	case VersionFamilyGQUIC:
+		return ComposeGQUICVersionNegotiation(dest, greased), nil
+	case VersionFamilyIETF:
+		return composeIETFVersionNegotiation(dest, src, greased)
+	default:
+		return nil, fmt.Errorf("%w: %d", errInvalidVersionFamily, family)
+	}
+}
+
+// composeIETFVersionNegotiation composes an IETF QUIC Version Negotiation
+// Packet. Its Version field is always 0: that's what tells a client it's
+// looking at a Version Negotiation Packet rather than one in a single
+// version it might not understand.
+func composeIETFVersionNegotiation(dest, src protocol.ConnectionID, versions []protocol.VersionNumber) ([]byte, error) {
+	if err := validateConnectionIDLen(len(dest)); err != nil {
+		return nil, err
+	}
+	if err := validateConnectionIDLen(len(src)); err != nil {
+		return nil, err
+	}
+	b := &bytes.Buffer{}
+	// the low 6 bits of the first byte (beyond the fixed bit, which must
+	// still be set so the packet is recognizable as QUIC) are unused for a
+	// Version Negotiation Packet; RFC 9000 recommends randomizing them so
+	// that a client can't come to rely on a fixed value there
+	randByte := make([]byte, 1)
+	if _, err := rand.Read(randByte); err != nil {
+		return nil, err
+	}
+	b.WriteByte(ietfLongHeaderFlag | ietfLongHeaderFixedBit | randByte[0]&0x3f)
+	if err := binary.Write(b, binary.BigEndian, uint32(protocol.VersionWhatever)); err != nil {
+		return nil, err
+	}
+	b.WriteByte(uint8(len(dest)))
+	b.Write(dest)
+	b.WriteByte(uint8(len(src)))
+	b.Write(src)
+	for _, v := range versions {
+		if err := binary.Write(b, binary.BigEndian, uint32(v)); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// ParseIETFVersionNegotiation parses an IETF QUIC Version Negotiation
+// Packet, as composed by WriteVersionNegotiation with VersionFamilyIETF. The
+// caller must not have consumed the first byte yet.
+func ParseIETFVersionNegotiation(b *bytes.Reader) (dest, src protocol.ConnectionID, versions []protocol.VersionNumber, err error) {
+	firstByte, err := b.ReadByte()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if firstByte&ietfLongHeaderFixedBit == 0 {
+		return nil, nil, nil, errors.New("wire: Version Negotiation Packet without the fixed bit set")
+	}
+	var v uint32
+	if err := binary.Read(b, binary.BigEndian, &v); err != nil {
+		return nil, nil, nil, err
+	}
+	if v != uint32(protocol.VersionWhatever) {
+		return nil, nil, nil, fmt.Errorf("wire: not a Version Negotiation Packet: version %#x", v)
+	}
+
+	destLenByte, err := b.ReadByte()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateConnectionIDLen(int(destLenByte)); err != nil {
+		return nil, nil, nil, err
+	}
+	dest = make(protocol.ConnectionID, destLenByte)
+	if _, err := io.ReadFull(b, dest); err != nil {
+		return nil, nil, nil, err
+	}
+
+	srcLenByte, err := b.ReadByte()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := validateConnectionIDLen(int(srcLenByte)); err != nil {
+		return nil, nil, nil, err
+	}
+	src = make(protocol.ConnectionID, srcLenByte)
+	if _, err := io.ReadFull(b, src); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if b.Len()%4 != 0 {
+		return nil, nil, nil, errors.New("wire: invalid Version Negotiation Packet")
+	}
+	for b.Len() > 0 {
+		var v uint32
+		if err := binary.Read(b, binary.BigEndian, &v); err != nil {
+			return nil, nil, nil, err
+		}
+		versions = append(versions, protocol.VersionNumber(v))
+	}
+	return dest, src, versions, nil
+}
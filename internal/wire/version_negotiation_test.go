@@ -0,0 +1,59 @@
+package wire
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Version Negotiation", func() {
+	offered := []protocol.VersionNumber{protocol.Version39, protocol.VersionTLS}
+
+	It("writes and parses a gQUIC Version Negotiation Packet, with a greased version", func() {
+		connID := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}
+		data, err := WriteVersionNegotiation(VersionFamilyGQUIC, connID, nil, offered)
+		Expect(err).ToNot(HaveOccurred())
+
+		hdr, err := parsePublicHeader(bytes.NewReader(data), protocol.PerspectiveServer)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hdr.IsVersionNegotiation).To(BeTrue())
+		Expect(hdr.SupportedVersions).To(HaveLen(len(offered) + 1))
+		Expect(hdr.SupportedVersions[:len(offered)]).To(Equal(offered))
+		Expect(uint32(hdr.SupportedVersions[len(offered)]) & 0x0f0f0f0f).To(Equal(uint32(0x0a0a0a0a)))
+	})
+
+	It("writes and parses an IETF Version Negotiation Packet, with a greased version", func() {
+		dest := protocol.ConnectionID{1, 2, 3, 4}
+		src := protocol.ConnectionID{5, 6, 7, 8, 9, 10}
+		data, err := WriteVersionNegotiation(VersionFamilyIETF, dest, src, offered)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data[0] & ietfLongHeaderFlag).ToNot(BeZero())
+		Expect(data[0] & ietfLongHeaderFixedBit).ToNot(BeZero())
+
+		parsedDest, parsedSrc, versions, err := ParseIETFVersionNegotiation(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsedDest).To(Equal(dest))
+		Expect(parsedSrc).To(Equal(src))
+		Expect(versions).To(HaveLen(len(offered) + 1))
+		Expect(versions[:len(offered)]).To(Equal(offered))
+	})
+
+	It("rejects an IETF Version Negotiation Packet without the fixed bit set", func() {
+		dest := protocol.ConnectionID{1, 2, 3, 4}
+		src := protocol.ConnectionID{5, 6, 7, 8, 9, 10}
+		data, err := WriteVersionNegotiation(VersionFamilyIETF, dest, src, offered)
+		Expect(err).ToNot(HaveOccurred())
+		data[0] &^= ietfLongHeaderFixedBit
+
+		_, _, _, err = ParseIETFVersionNegotiation(bytes.NewReader(data))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects an unknown version family", func() {
+		_, err := WriteVersionNegotiation(VersionFamily(42), protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}, nil, offered)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,41 @@
+package qerr
+
+// ErrorCode can be used as a normal error without reason
+type ErrorCode uint32
+
+// Error codes defined by the QUIC wire spec
+const (
+	InternalError                   ErrorCode = 1
+	InvalidPacketHeader             ErrorCode = 3
+	InvalidFrameData                ErrorCode = 4
+	InvalidConnectionID             ErrorCode = 17
+	DecryptionFailure               ErrorCode = 12
+	ServerErrorProcessingStream     ErrorCode = 13
+	InvalidVersionNegotiationPacket ErrorCode = 20
+	InvalidPublicResetPacket        ErrorCode = 21
+	PeerGoingAway                   ErrorCode = 16
+	HandshakeFailed                 ErrorCode = 29
+	VersionNegotiationMismatch      ErrorCode = 37
+)
+
+var errorCodeToString = map[ErrorCode]string{
+	InternalError:                   "InternalError",
+	InvalidPacketHeader:             "InvalidPacketHeader",
+	InvalidFrameData:                "InvalidFrameData",
+	InvalidConnectionID:             "InvalidConnectionID",
+	DecryptionFailure:               "DecryptionFailure",
+	ServerErrorProcessingStream:     "ServerErrorProcessingStream",
+	InvalidVersionNegotiationPacket: "InvalidVersionNegotiationPacket",
+	InvalidPublicResetPacket:        "InvalidPublicResetPacket",
+	PeerGoingAway:                   "PeerGoingAway",
+	HandshakeFailed:                 "HandshakeFailed",
+	VersionNegotiationMismatch:      "VersionNegotiationMismatch",
+}
+
+// Error returns the name of this error code
+func (e ErrorCode) Error() string {
+	if s, ok := errorCodeToString[e]; ok {
+		return s
+	}
+	return "unknown error code"
+}
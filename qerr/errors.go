@@ -0,0 +1,45 @@
+package qerr
+
+import "fmt"
+
+// A QuicError consists of an error code plus a error reason
+type QuicError struct {
+	ErrorCode    ErrorCode
+	ErrorMessage string
+}
+
+// NewError creates a new QuicError instance
+func NewError(errorCode ErrorCode, errorMessage string) *QuicError {
+	return &QuicError{
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}
+}
+
+// Error creates a new QuicError instance from an error code and a reason,
+// returned as an error.
+func Error(errorCode ErrorCode, errorMessage string) error {
+	return &QuicError{
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}
+}
+
+func (e *QuicError) Error() string {
+	if len(e.ErrorMessage) == 0 {
+		return e.ErrorCode.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.ErrorCode.Error(), e.ErrorMessage)
+}
+
+// ToQuicError converts an arbitrary error to a QuicError. If the error is
+// not a QuicError itself, it is treated as an InternalError.
+func ToQuicError(err error) *QuicError {
+	if err == nil {
+		return nil
+	}
+	if qErr, ok := err.(*QuicError); ok {
+		return qErr
+	}
+	return NewError(InternalError, err.Error())
+}
@@ -0,0 +1,100 @@
+// Package qlog implements a wire.Tracer that emits qlog-compatible NDJSON,
+// suitable for loading into qvis (https://qvis.quictools.info) when hunting
+// a stalled-connection bug.
+package qlog
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/wire"
+)
+
+// event is a single qlog NDJSON record.
+type event struct {
+	Time string      `json:"time"`
+	Name string      `json:"name"`
+	Data interface{} `json:"data"`
+}
+
+// Tracer writes wire.Tracer events as newline-delimited qlog-style JSON, one
+// event per line, to w.
+type Tracer struct {
+	w io.Writer
+}
+
+var _ wire.Tracer = &Tracer{}
+
+// NewTracer creates a Tracer that writes its NDJSON events to w.
+func NewTracer(w io.Writer) *Tracer {
+	return &Tracer{w: w}
+}
+
+func (t *Tracer) write(name string, data interface{}) {
+	// Encode fails either on a malformed data value (a type json.Marshal
+	// can't handle) or on a failed write to w; there's no reasonable
+	// recovery for either, so drop the error like logPublicHeader
+	// has always dropped its own formatting failures.
+	_ = json.NewEncoder(t.w).Encode(event{
+		Time: time.Now().UTC().Format(time.RFC3339Nano),
+		Name: name,
+		Data: data,
+	})
+}
+
+// ReceivedPacket implements wire.Tracer.
+func (t *Tracer) ReceivedPacket(hdr *wire.Header, size protocol.ByteCount) {
+	t.write("transport:packet_received", map[string]interface{}{
+		"header": headerFields(hdr),
+		"raw":    map[string]interface{}{"length": size},
+	})
+}
+
+// SentPacket implements wire.Tracer.
+func (t *Tracer) SentPacket(hdr *wire.Header, size protocol.ByteCount) {
+	t.write("transport:packet_sent", map[string]interface{}{
+		"header": headerFields(hdr),
+		"raw":    map[string]interface{}{"length": size},
+	})
+}
+
+// DroppedPacket implements wire.Tracer.
+func (t *Tracer) DroppedPacket(reason string) {
+	t.write("transport:packet_dropped", map[string]interface{}{"trigger": reason})
+}
+
+// NegotiatedVersion implements wire.Tracer.
+func (t *Tracer) NegotiatedVersion(chosen protocol.VersionNumber, clientVersions, serverVersions []protocol.VersionNumber) {
+	t.write("transport:version_information", map[string]interface{}{
+		"chosen_version":  chosen.String(),
+		"client_versions": versionStrings(clientVersions),
+		"server_versions": versionStrings(serverVersions),
+	})
+}
+
+func headerFields(hdr *wire.Header) map[string]interface{} {
+	fields := map[string]interface{}{
+		"dcid":          hdr.DestConnectionID.String(),
+		"scid":          hdr.SrcConnectionID.String(),
+		"packet_number": hdr.PacketNumber,
+	}
+	if hdr.IsLongHeader {
+		fields["packet_type"] = hdr.Type.String()
+	} else {
+		fields["packet_type"] = "1RTT"
+	}
+	if hdr.Version != protocol.VersionWhatever {
+		fields["version"] = hdr.Version.String()
+	}
+	return fields
+}
+
+func versionStrings(vs []protocol.VersionNumber) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = v.String()
+	}
+	return out
+}
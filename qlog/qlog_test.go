@@ -0,0 +1,41 @@
+package qlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/wire"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracer", func() {
+	It("emits one qlog JSON event per line", func() {
+		buf := &bytes.Buffer{}
+		tracer := NewTracer(buf)
+
+		hdr := &wire.Header{
+			DestConnectionID: protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			SrcConnectionID:  protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8},
+			Version:          protocol.Version39,
+		}
+		tracer.ReceivedPacket(hdr, 42)
+		tracer.DroppedPacket("invalid packet header")
+
+		scanner := bufio.NewScanner(buf)
+		var names []string
+		for scanner.Scan() {
+			var e struct {
+				Name string `json:"name"`
+				Data json.RawMessage
+			}
+			Expect(json.Unmarshal(scanner.Bytes(), &e)).To(Succeed())
+			Expect(e.Data).ToNot(BeEmpty())
+			names = append(names, e.Name)
+		}
+		Expect(names).To(Equal([]string{"transport:packet_received", "transport:packet_dropped"}))
+	})
+})
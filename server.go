@@ -0,0 +1,74 @@
+package quic
+
+import (
+	"net"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/wire"
+)
+
+// Server accepts incoming QUIC connections.
+type Server struct {
+	// Config holds the server's configuration, including the
+	// ConnectionIDGenerator used to mint Source Connection IDs. A nil
+	// Config is equivalent to the zero value.
+	Config *Config
+
+	// TokenValidator checks the address-validation token on incoming
+	// Initial packets, if set. A server should only allocate session state
+	// for an Initial once its token has been validated (or once it has
+	// sent a Retry asking for one), to avoid being used as a reflection
+	// amplifier for spoofed source addresses.
+	TokenValidator *TokenValidator
+}
+
+// newConnectionID generates a new Source Connection ID for a session,
+// using the Server's configured ConnectionIDGenerator (or the default,
+// random one, if none is set). This package has no session-accept path and
+// no NEW_CONNECTION_ID frame handling, so nothing here calls it outside of
+// tests.
+func (s *Server) newConnectionID() (protocol.ConnectionID, error) {
+	gen := s.Config.connectionIDGenerator()
+	return gen.GenerateConnectionID(gen.ConnectionIDLen())
+}
+
+// SetSupportedVersions sets the ordered list of QUIC versions this Server
+// advertises to a client that requested a version it doesn't support.
+// Versions are tried in the order given. Without a call to
+// SetSupportedVersions, a Server advertises protocol.SupportedVersions.
+func (s *Server) SetSupportedVersions(versions []protocol.VersionNumber) {
+	if s.Config == nil {
+		s.Config = &Config{}
+	}
+	s.Config.SupportedVersions = versions
+}
+
+// supportedVersions returns the versions this Server advertises in a
+// Version Negotiation Packet.
+func (s *Server) supportedVersions() []protocol.VersionNumber {
+	if s.Config != nil && len(s.Config.SupportedVersions) > 0 {
+		return s.Config.SupportedVersions
+	}
+	return protocol.SupportedVersions
+}
+
+// versionNegotiation composes a Version Negotiation Packet, in the given
+// wire form, listing the Server's supportedVersions, for a client whose
+// Initial packet used dest and src as its Destination and Source
+// Connection ID.
+func (s *Server) versionNegotiation(family wire.VersionFamily, dest, src protocol.ConnectionID) ([]byte, error) {
+	return wire.WriteVersionNegotiation(family, dest, src, s.supportedVersions())
+}
+
+// shouldAllocateSession reports whether the server may start allocating
+// state for an Initial packet arriving from raddr with the given token.
+// A nil or missing TokenValidator means address validation is disabled.
+func (s *Server) shouldAllocateSession(token []byte, raddr net.Addr) bool {
+	if s.TokenValidator == nil {
+		return true
+	}
+	if token == nil {
+		return false
+	}
+	return s.TokenValidator.Validate(token, raddr) == nil
+}
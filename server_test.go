@@ -0,0 +1,48 @@
+package quic
+
+import (
+	"bytes"
+
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/wire"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	Describe("shouldAllocateSession", func() {
+		It("allows allocation when no TokenValidator is configured", func() {
+			s := &Server{}
+			Expect(s.shouldAllocateSession(nil, nil)).To(BeTrue())
+		})
+	})
+
+	Describe("SetSupportedVersions", func() {
+		It("advertises protocol.SupportedVersions by default", func() {
+			s := &Server{}
+			Expect(s.supportedVersions()).To(Equal(protocol.SupportedVersions))
+		})
+
+		It("advertises the configured versions once set", func() {
+			s := &Server{}
+			custom := []protocol.VersionNumber{protocol.VersionTLS}
+			s.SetSupportedVersions(custom)
+			Expect(s.supportedVersions()).To(Equal(custom))
+		})
+
+		It("feeds the configured versions into the Version Negotiation Packet it writes", func() {
+			s := &Server{}
+			s.SetSupportedVersions([]protocol.VersionNumber{protocol.Version39})
+
+			dest := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}
+			data, err := s.versionNegotiation(wire.VersionFamilyGQUIC, dest, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			hdr, err := wire.ParseHeader(bytes.NewReader(data), protocol.PerspectiveServer, false, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hdr.SupportedVersions).To(HaveLen(2)) // the configured version, plus the greased one
+			Expect(hdr.SupportedVersions[0]).To(Equal(protocol.Version39))
+		})
+	})
+})
@@ -0,0 +1,51 @@
+package quic
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/lucas-clemente/quic-go/internal/wire"
+)
+
+// session is the state associated with a single QUIC connection.
+type session struct {
+	mutex sync.Mutex
+
+	closed    bool
+	closeErr  error
+	closeChan chan struct{}
+}
+
+func newSession() *session {
+	return &session{closeChan: make(chan struct{})}
+}
+
+// closeLocal tears down the session with the given error, without sending
+// anything to the peer. It's used for errors that originate locally, and
+// for Public Resets: since the peer that sent one has by definition
+// already discarded its own state for this connection, there's no one left
+// to notify.
+func (s *session) closeLocal(e error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.closeErr = e
+	close(s.closeChan)
+	return nil
+}
+
+// handlePublicReset is called when a Public Reset packet is received for
+// this session. Older quic-go deployments only logged and dropped these
+// packets, which could leave a session stuck until the idle timeout fired;
+// closing eagerly here with a distinguishable error type avoids that
+// deadlock.
+func (s *session) handlePublicReset(data []byte) error {
+	pr, err := wire.ParsePublicReset(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return s.closeLocal(&PublicResetError{RejectedPacketNumber: pr.RejectedPacketNumber})
+}
@@ -0,0 +1,25 @@
+package quic
+
+import (
+	"github.com/lucas-clemente/quic-go/internal/protocol"
+	"github.com/lucas-clemente/quic-go/internal/wire"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Session", func() {
+	It("closes when it receives a Public Reset", func() {
+		sess := newSession()
+		connID := protocol.ConnectionID{1, 2, 3, 4, 5, 6, 7, 8}
+		data := wire.WritePublicReset(connID, 0x42, 0x1234, nil)
+
+		err := sess.handlePublicReset(data[1+len(connID):])
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(sess.closeChan).Should(BeClosed())
+		var resetErr *PublicResetError
+		Expect(sess.closeErr).To(BeAssignableToTypeOf(resetErr))
+		Expect(sess.closeErr.(*PublicResetError).RejectedPacketNumber).To(Equal(protocol.PacketNumber(0x42)))
+	})
+})
@@ -0,0 +1,50 @@
+package quic
+
+import (
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Token", func() {
+	key := []byte("supersecretkey16")
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234}
+
+	It("mints and validates a token for the right address", func() {
+		gen, err := NewTokenGenerator(key)
+		Expect(err).ToNot(HaveOccurred())
+		val, err := NewTokenValidator(key, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		token, err := gen.NewToken(addr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(val.Validate(token, addr)).To(Succeed())
+	})
+
+	It("rejects a token presented by a different address", func() {
+		gen, err := NewTokenGenerator(key)
+		Expect(err).ToNot(HaveOccurred())
+		val, err := NewTokenValidator(key, time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+
+		token, err := gen.NewToken(addr)
+		Expect(err).ToNot(HaveOccurred())
+
+		other := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4321}
+		Expect(val.Validate(token, other)).To(MatchError(errInvalidToken))
+	})
+
+	It("rejects an expired token", func() {
+		gen, err := NewTokenGenerator(key)
+		Expect(err).ToNot(HaveOccurred())
+		val, err := NewTokenValidator(key, time.Nanosecond)
+		Expect(err).ToNot(HaveOccurred())
+
+		token, err := gen.NewToken(addr)
+		Expect(err).ToNot(HaveOccurred())
+		time.Sleep(time.Millisecond)
+		Expect(val.Validate(token, addr)).To(MatchError(errInvalidToken))
+	})
+})